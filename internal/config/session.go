@@ -0,0 +1,69 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type Session struct {
+	ImplicitHosting   bool
+	InactiveCursors   bool
+	MercifulReconnect bool
+	CookieEnabled     bool
+	APIToken          string
+
+	// StoreBackend selects the SessionStore implementation: "memory" (the
+	// default) or "sqlite" for a durable, restart-surviving store.
+	StoreBackend string
+	// StoreSQLiteFile is the database file used by the "sqlite" backend.
+	StoreSQLiteFile string
+}
+
+func (Session) Init(cmd *cobra.Command) error {
+	cmd.PersistentFlags().Bool("session.implicit_hosting", true, "implicit hosting, if client don't have control, first to interact with remote gains it")
+	if err := viper.BindPFlag("session.implicit_hosting", cmd.PersistentFlags().Lookup("session.implicit_hosting")); err != nil {
+		return err
+	}
+
+	cmd.PersistentFlags().Bool("session.inactive_cursors", false, "show inactive cursors of other users, when they are not the host")
+	if err := viper.BindPFlag("session.inactive_cursors", cmd.PersistentFlags().Lookup("session.inactive_cursors")); err != nil {
+		return err
+	}
+
+	cmd.PersistentFlags().Bool("session.merciful_reconnect", true, "let session reconnect even from a different IP address")
+	if err := viper.BindPFlag("session.merciful_reconnect", cmd.PersistentFlags().Lookup("session.merciful_reconnect")); err != nil {
+		return err
+	}
+
+	cmd.PersistentFlags().Bool("session.cookie_enabled", true, "use cookies for session tracking instead of a query parameter")
+	if err := viper.BindPFlag("session.cookie_enabled", cmd.PersistentFlags().Lookup("session.cookie_enabled")); err != nil {
+		return err
+	}
+
+	cmd.PersistentFlags().String("session.api_token", "", "API token for the access to the REST API")
+	if err := viper.BindPFlag("session.api_token", cmd.PersistentFlags().Lookup("session.api_token")); err != nil {
+		return err
+	}
+
+	cmd.PersistentFlags().String("session.store_backend", "memory", "session store backend, one of: memory, sqlite")
+	if err := viper.BindPFlag("session.store_backend", cmd.PersistentFlags().Lookup("session.store_backend")); err != nil {
+		return err
+	}
+
+	cmd.PersistentFlags().String("session.store_sqlite_file", "./sessions.sqlite", "path to the sqlite database file, when store_backend is sqlite")
+	if err := viper.BindPFlag("session.store_sqlite_file", cmd.PersistentFlags().Lookup("session.store_sqlite_file")); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Session) Set() {
+	s.ImplicitHosting = viper.GetBool("session.implicit_hosting")
+	s.InactiveCursors = viper.GetBool("session.inactive_cursors")
+	s.MercifulReconnect = viper.GetBool("session.merciful_reconnect")
+	s.CookieEnabled = viper.GetBool("session.cookie_enabled")
+	s.APIToken = viper.GetString("session.api_token")
+	s.StoreBackend = viper.GetString("session.store_backend")
+	s.StoreSQLiteFile = viper.GetString("session.store_sqlite_file")
+}
@@ -0,0 +1,87 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"gitlab.com/demodesk/neko/server/internal/types"
+	"gitlab.com/demodesk/neko/server/internal/types/message"
+)
+
+// ClipboardRouter exposes the clipboard over the REST API, mounted under the
+// authenticated room router, so admin clients can paste images or HTML
+// fragments without going through the websocket.
+type ClipboardRouter struct {
+	desktop types.DesktopManager
+}
+
+func NewClipboardRouter(desktop types.DesktopManager) *ClipboardRouter {
+	return &ClipboardRouter{
+		desktop: desktop,
+	}
+}
+
+func (router *ClipboardRouter) Mount(r chi.Router) {
+	r.Get("/clipboard/targets", router.targets)
+	r.Get("/clipboard", router.read)
+	r.Post("/clipboard", router.write)
+}
+
+func (router *ClipboardRouter) targets(w http.ResponseWriter, r *http.Request) {
+	targets, err := router.desktop.ClipboardTargets()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	mimeTypes := make([]string, len(targets))
+	for i, target := range targets {
+		mimeTypes[i] = target.MimeType
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(message.ClipboardTargets{
+		MimeTypes: mimeTypes,
+	})
+}
+
+func (router *ClipboardRouter) read(w http.ResponseWriter, r *http.Request) {
+	mimeType := r.URL.Query().Get("mime_type")
+	if mimeType == "" {
+		mimeType = "text/plain"
+	}
+
+	data, err := router.desktop.ClipboardRead(mimeType)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", mimeType)
+	_, _ = w.Write(data)
+}
+
+func (router *ClipboardRouter) write(w http.ResponseWriter, r *http.Request) {
+	var payload message.ClipboardWrite
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entries := make([]types.ClipboardEntry, len(payload.Entries))
+	for i, entry := range payload.Entries {
+		entries[i] = types.ClipboardEntry{
+			MimeType: entry.MimeType,
+			Data:     entry.Data,
+		}
+	}
+
+	if err := router.desktop.ClipboardWrite(entries); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
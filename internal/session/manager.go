@@ -3,6 +3,7 @@ package session
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/kataras/go-events"
 	"github.com/rs/zerolog"
@@ -14,15 +15,24 @@ import (
 )
 
 func New(config *config.Session) *SessionManagerCtx {
+	store, err := NewSessionStore(config)
+	if err != nil {
+		log.Panic().Err(err).Msg("unable to create session store")
+	}
+
 	manager := &SessionManagerCtx{
-		logger:   log.With().Str("module", "session").Logger(),
-		config:   config,
-		tokens:   make(map[string]string),
-		sessions: make(map[string]*SessionCtx),
-		cursors:  make(map[types.Session][]types.Cursor),
-		emmiter:  events.New(),
+		logger:    log.With().Str("module", "session").Logger(),
+		config:    config,
+		store:     store,
+		tokens:    make(map[string]string),
+		sessions:  make(map[string]*SessionCtx),
+		cursors:   make(map[types.Session][]types.Cursor),
+		emmiter:   events.New(),
+		persistCh: make(chan persistOp, 256),
 	}
 
+	go manager.persistWorker()
+
 	// create API session
 	if config.APIToken != "" {
 		manager.apiSession = &SessionCtx{
@@ -42,12 +52,39 @@ func New(config *config.Session) *SessionManagerCtx {
 		}
 	}
 
+	// rehydrate sessions persisted by a previous run (or another replica),
+	// so a restart does not invalidate every logged-in user
+	entries, err := store.List()
+	if err != nil {
+		manager.logger.Err(err).Msg("unable to list persisted sessions")
+	}
+
+	for _, entry := range entries {
+		session := &SessionCtx{
+			id:      entry.ID,
+			token:   entry.Token,
+			manager: manager,
+			logger:  manager.logger.With().Str("session_id", entry.ID).Logger(),
+			profile: entry.Profile,
+		}
+
+		manager.tokens[entry.Token] = entry.ID
+		manager.sessions[entry.ID] = session
+
+		if entry.IsHost {
+			manager.host = session
+		}
+
+		manager.emmiter.Emit("created", session)
+	}
+
 	return manager
 }
 
 type SessionManagerCtx struct {
 	logger zerolog.Logger
 	config *config.Session
+	store  SessionStore
 
 	tokens     map[string]string
 	sessions   map[string]*SessionCtx
@@ -61,6 +98,8 @@ type SessionManagerCtx struct {
 
 	emmiter    events.EventEmmiter
 	apiSession *SessionCtx
+
+	persistCh chan persistOp
 }
 
 func (manager *SessionManagerCtx) Create(id string, profile types.MemberProfile) (types.Session, string, error) {
@@ -90,8 +129,11 @@ func (manager *SessionManagerCtx) Create(id string, profile types.MemberProfile)
 
 	manager.tokens[token] = id
 	manager.sessions[id] = session
+	entry := manager.toStoredSession(session)
 	manager.sessionsMu.Unlock()
 
+	manager.persist(entry)
+
 	manager.emmiter.Emit("created", session)
 	return session, token, nil
 }
@@ -106,8 +148,11 @@ func (manager *SessionManagerCtx) Update(id string, profile types.MemberProfile)
 	}
 
 	session.profile = profile
+	entry := manager.toStoredSession(session)
 	manager.sessionsMu.Unlock()
 
+	manager.persist(entry)
+
 	manager.emmiter.Emit("profile_changed", session)
 	session.profileChanged()
 	return nil
@@ -125,6 +170,8 @@ func (manager *SessionManagerCtx) Delete(id string) error {
 	delete(manager.sessions, id)
 	manager.sessionsMu.Unlock()
 
+	manager.persistDelete(id)
+
 	if session.State().IsConnected {
 		session.GetWebSocketPeer().Destroy("session deleted")
 	}
@@ -174,15 +221,90 @@ func (manager *SessionManagerCtx) List() []types.Session {
 	return sessions
 }
 
+// ---
+// persistence
+// ---
+
+// toStoredSession builds a StoredSession snapshot of session. The caller must
+// hold sessionsMu, so it never races Update's write of session.profile.
+func (manager *SessionManagerCtx) toStoredSession(session *SessionCtx) StoredSession {
+	return StoredSession{
+		ID:       session.id,
+		Token:    session.token,
+		Profile:  session.profile,
+		IsHost:   manager.GetHost() == types.Session(session),
+		LastSeen: time.Now(),
+	}
+}
+
+// persistOp is one queued write for persistWorker: either an upsert of
+// entry, or a delete of id.
+type persistOp struct {
+	isDelete bool
+	id       string
+	entry    StoredSession
+}
+
+// persist enqueues entry to be written to the store off the hot path: the
+// session lock is never held during I/O, so Get/GetByToken stay lock-free of
+// storage. Writes for a given session are applied by persistWorker in the
+// same order persist/persistDelete were called, so a profile change followed
+// by a host handoff can never land in the store out of order.
+func (manager *SessionManagerCtx) persist(entry StoredSession) {
+	manager.persistCh <- persistOp{entry: entry}
+}
+
+// persistDelete enqueues a deletion of id, ordered the same way as persist.
+func (manager *SessionManagerCtx) persistDelete(id string) {
+	manager.persistCh <- persistOp{isDelete: true, id: id}
+}
+
+// persistWorker is the sole writer to the store, draining persistCh in
+// submission order.
+func (manager *SessionManagerCtx) persistWorker() {
+	for op := range manager.persistCh {
+		id := op.id
+		var err error
+		if op.isDelete {
+			err = manager.store.Delete(op.id)
+		} else {
+			id = op.entry.ID
+			err = manager.store.Update(op.entry)
+		}
+
+		if err != nil {
+			manager.logger.Err(err).Str("session_id", id).Msg("unable to persist session")
+		}
+	}
+}
+
 // ---
 // host
 // ---
 
 func (manager *SessionManagerCtx) SetHost(host types.Session) {
 	manager.hostMu.Lock()
+	prev := manager.host
 	manager.host = host
 	manager.hostMu.Unlock()
 
+	// persist after the host pointer is updated, so toStoredSession computes
+	// IsHost against the new host: this clears the flag on the previous
+	// host's row instead of leaving two rows marked as host in the store.
+	manager.sessionsMu.Lock()
+	var entries []StoredSession
+	if prevSession, ok := prev.(*SessionCtx); ok && prev != host {
+		entries = append(entries, manager.toStoredSession(prevSession))
+	}
+	if session, ok := host.(*SessionCtx); ok {
+		entries = append(entries, manager.toStoredSession(session))
+	}
+	manager.sessionsMu.Unlock()
+
+	for _, entry := range entries {
+		manager.persist(entry)
+	}
+
 	manager.emmiter.Emit("host_changed", host)
 }
 
@@ -0,0 +1,114 @@
+package session
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gitlab.com/demodesk/neko/server/internal/config"
+	"gitlab.com/demodesk/neko/server/internal/types"
+)
+
+// StoredSession is the durable representation of a session, persisted by a
+// SessionStore implementation. It intentionally omits runtime-only state
+// (websocket/webrtc peers, connection status) which cannot survive a restart.
+type StoredSession struct {
+	ID       string
+	Token    string
+	Profile  types.MemberProfile
+	IsHost   bool
+	LastSeen time.Time
+}
+
+// SessionStore persists sessions so they survive a server restart and can be
+// shared across multiple neko replicas behind a load balancer. Implementations
+// must be safe for concurrent use.
+type SessionStore interface {
+	Create(entry StoredSession) error
+	Update(entry StoredSession) error
+	Delete(id string) error
+	Get(id string) (StoredSession, bool, error)
+	GetByToken(token string) (StoredSession, bool, error)
+	List() ([]StoredSession, error)
+}
+
+// NewSessionStore selects a SessionStore backend based on config. It defaults
+// to the in-memory store when no durable backend is configured, so a bare
+// config keeps today's behaviour.
+func NewSessionStore(config *config.Session) (SessionStore, error) {
+	switch config.StoreBackend {
+	case "sqlite":
+		return newSqliteSessionStore(config.StoreSQLiteFile)
+	case "", "memory":
+		return newMemorySessionStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown session store backend %q", config.StoreBackend)
+	}
+}
+
+type memorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]StoredSession
+	tokens   map[string]string
+}
+
+func newMemorySessionStore() *memorySessionStore {
+	return &memorySessionStore{
+		sessions: make(map[string]StoredSession),
+		tokens:   make(map[string]string),
+	}
+}
+
+func (s *memorySessionStore) Create(entry StoredSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[entry.ID] = entry
+	s.tokens[entry.Token] = entry.ID
+	return nil
+}
+
+func (s *memorySessionStore) Update(entry StoredSession) error {
+	return s.Create(entry)
+}
+
+func (s *memorySessionStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.sessions[id]; ok {
+		delete(s.tokens, entry.Token)
+	}
+	delete(s.sessions, id)
+	return nil
+}
+
+func (s *memorySessionStore) Get(id string) (StoredSession, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.sessions[id]
+	return entry, ok, nil
+}
+
+func (s *memorySessionStore) GetByToken(token string) (StoredSession, bool, error) {
+	s.mu.Lock()
+	id, ok := s.tokens[token]
+	s.mu.Unlock()
+
+	if !ok {
+		return StoredSession{}, false, nil
+	}
+	return s.Get(id)
+}
+
+func (s *memorySessionStore) List() ([]StoredSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]StoredSession, 0, len(s.sessions))
+	for _, entry := range s.sessions {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,123 @@
+package session
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqliteSessionStore is a durable SessionStore backend, so session state
+// survives a restart and can be shared between multiple neko replicas
+// pointed at the same database file.
+type sqliteSessionStore struct {
+	db *sql.DB
+}
+
+func newSqliteSessionStore(file string) (*sqliteSessionStore, error) {
+	db, err := sql.Open("sqlite3", file)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS sessions (
+			id        TEXT PRIMARY KEY,
+			token     TEXT UNIQUE NOT NULL,
+			profile   TEXT NOT NULL,
+			is_host   INTEGER NOT NULL DEFAULT 0,
+			last_seen INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteSessionStore{db: db}, nil
+}
+
+func (s *sqliteSessionStore) Create(entry StoredSession) error {
+	profile, err := json.Marshal(entry.Profile)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO sessions (id, token, profile, is_host, last_seen)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			token = excluded.token,
+			profile = excluded.profile,
+			is_host = excluded.is_host,
+			last_seen = excluded.last_seen
+	`, entry.ID, entry.Token, profile, entry.IsHost, entry.LastSeen.Unix())
+	return err
+}
+
+func (s *sqliteSessionStore) Update(entry StoredSession) error {
+	return s.Create(entry)
+}
+
+func (s *sqliteSessionStore) Delete(id string) error {
+	_, err := s.db.Exec(`DELETE FROM sessions WHERE id = ?`, id)
+	return err
+}
+
+func (s *sqliteSessionStore) Get(id string) (StoredSession, bool, error) {
+	row := s.db.QueryRow(`SELECT id, token, profile, is_host, last_seen FROM sessions WHERE id = ?`, id)
+	return s.scan(row)
+}
+
+func (s *sqliteSessionStore) GetByToken(token string) (StoredSession, bool, error) {
+	row := s.db.QueryRow(`SELECT id, token, profile, is_host, last_seen FROM sessions WHERE token = ?`, token)
+	return s.scan(row)
+}
+
+func (s *sqliteSessionStore) scan(row *sql.Row) (StoredSession, bool, error) {
+	var entry StoredSession
+	var profile string
+	var lastSeen int64
+
+	err := row.Scan(&entry.ID, &entry.Token, &profile, &entry.IsHost, &lastSeen)
+	if err == sql.ErrNoRows {
+		return StoredSession{}, false, nil
+	}
+	if err != nil {
+		return StoredSession{}, false, err
+	}
+
+	if err := json.Unmarshal([]byte(profile), &entry.Profile); err != nil {
+		return StoredSession{}, false, err
+	}
+	entry.LastSeen = time.Unix(lastSeen, 0)
+
+	return entry, true, nil
+}
+
+func (s *sqliteSessionStore) List() ([]StoredSession, error) {
+	rows, err := s.db.Query(`SELECT id, token, profile, is_host, last_seen FROM sessions`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []StoredSession
+	for rows.Next() {
+		var entry StoredSession
+		var profile string
+		var lastSeen int64
+
+		if err := rows.Scan(&entry.ID, &entry.Token, &profile, &entry.IsHost, &lastSeen); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(profile), &entry.Profile); err != nil {
+			return nil, err
+		}
+		entry.LastSeen = time.Unix(lastSeen, 0)
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
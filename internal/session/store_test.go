@@ -0,0 +1,84 @@
+package session
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gitlab.com/demodesk/neko/server/internal/types"
+)
+
+func testStoreRoundTrip(t *testing.T, store SessionStore) {
+	entry := StoredSession{
+		ID:    "user-1",
+		Token: "token-1",
+		Profile: types.MemberProfile{
+			Name:    "Jane",
+			IsAdmin: true,
+		},
+		IsHost:   true,
+		LastSeen: time.Now().Truncate(time.Second),
+	}
+
+	if err := store.Create(entry); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, ok, err := store.Get(entry.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !ok {
+		t.Fatalf("Get() expected entry to exist")
+	}
+	if got.Token != entry.Token || got.Profile.Name != entry.Profile.Name || !got.IsHost {
+		t.Fatalf("Get() = %+v, want %+v", got, entry)
+	}
+
+	if _, ok, err := store.GetByToken(entry.Token); err != nil || !ok {
+		t.Fatalf("GetByToken() = (_, %v, %v), want (_, true, nil)", ok, err)
+	}
+
+	entry.Profile.Name = "Jane Doe"
+	if err := store.Update(entry); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, _, err = store.Get(entry.ID)
+	if err != nil {
+		t.Fatalf("Get() after Update error = %v", err)
+	}
+	if got.Profile.Name != "Jane Doe" {
+		t.Fatalf("Get() after Update Profile.Name = %q, want %q", got.Profile.Name, "Jane Doe")
+	}
+
+	entries, err := store.List()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("List() = (%v, %v), want 1 entry", entries, err)
+	}
+
+	if err := store.Delete(entry.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, ok, err := store.Get(entry.ID); err != nil || ok {
+		t.Fatalf("Get() after Delete = (_, %v, %v), want (_, false, nil)", ok, err)
+	}
+
+	if _, ok, err := store.GetByToken(entry.Token); err != nil || ok {
+		t.Fatalf("GetByToken() after Delete = (_, %v, %v), want (_, false, nil)", ok, err)
+	}
+}
+
+func TestMemorySessionStore(t *testing.T) {
+	testStoreRoundTrip(t, newMemorySessionStore())
+}
+
+func TestSqliteSessionStore(t *testing.T) {
+	store, err := newSqliteSessionStore(filepath.Join(t.TempDir(), "sessions.sqlite"))
+	if err != nil {
+		t.Fatalf("newSqliteSessionStore() error = %v", err)
+	}
+
+	testStoreRoundTrip(t, store)
+}
@@ -9,16 +9,30 @@ type CursorImage struct {
 	Pixels []byte
 }
 
-type ScreenSize struct {
-	Width  int
-	Height int
-	Rate   int16
+// Output describes a single monitor in a (possibly multi-head) Xorg layout,
+// mirroring the fields xrandr reports for a connected output.
+type Output struct {
+	Name    string
+	X       int
+	Y       int
+	Width   int
+	Height  int
+	Rate    int16
+	Primary bool
 }
 
-type ScreenConfiguration struct {
-	Width  int
-	Height int
-	Rates  map[int]int16
+// ClipboardTarget describes a single MIME type offered by the current
+// clipboard owner, as advertised by an X11 TARGETS negotiation.
+type ClipboardTarget struct {
+	MimeType string
+}
+
+// ClipboardEntry carries one representation of the clipboard payload,
+// allowing a single copy/paste to offer several MIME types at once
+// (e.g. "text/html" alongside a "text/plain" fallback).
+type ClipboardEntry struct {
+	MimeType string
+	Data     []byte
 }
 
 type DesktopManager interface {
@@ -28,16 +42,15 @@ type DesktopManager interface {
 	OnAfterScreenSizeChange(listener func())
 
 	// xorg
-	ChangeScreenSize(width int, height int, rate int) error
-	Move(x, y int)
+	GetOutputs() ([]Output, error)
+	ConfigureOutputs(outputs []Output) error
+	Move(outputName string, x, y int)
 	Scroll(x, y int)
-	ButtonDown(code int) error
+	ButtonDown(outputName string, code int) error
 	KeyDown(code uint64) error
 	ButtonUp(code int) error
 	KeyUp(code uint64) error
 	ResetKeys()
-	ScreenConfigurations() map[int]ScreenConfiguration
-	GetScreenSize() *ScreenSize
 	SetKeyboardLayout(layout string)
 	SetKeyboardModifiers(NumLock int, CapsLock int, ScrollLock int)
 	GetCursorImage() *CursorImage
@@ -47,9 +60,10 @@ type DesktopManager interface {
 	OnEventError(listener func(error_code uint8, message string, request_code uint8, minor_code uint8))
 
 	// clipboard
-	ReadClipboard() string
-	WriteClipboard(data string)
+	ClipboardTargets() ([]ClipboardTarget, error)
+	ClipboardRead(mimeType string) ([]byte, error)
+	ClipboardWrite(entries []ClipboardEntry) error
 
 	// drop
-	DropFiles(x int, y int, files []string)
+	DropFiles(outputName string, x int, y int, files []string)
 }
@@ -0,0 +1,21 @@
+// Package event defines the websocket event names shared between server and
+// frontend. Constants here are the wire values of message.Header.Event and
+// of every outgoing session.Send(event, ...) call.
+package event
+
+const (
+	SCREEN_SET     = "screen/set"
+	SCREEN_UPDATED = "screen/updated"
+
+	CLIPBOARD_TARGETS = "clipboard/targets"
+	CLIPBOARD_DATA    = "clipboard/data"
+	CLIPBOARD_READ    = "clipboard/read"
+	CLIPBOARD_WRITE   = "clipboard/write"
+
+	CONTROL_MOVE       = "control/move"
+	CONTROL_BUTTONDOWN = "control/buttondown"
+
+	FILE_DROP = "file/drop"
+
+	LAYER_CHANGED = "signal/layer_changed"
+)
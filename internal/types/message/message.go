@@ -0,0 +1,64 @@
+// Package message defines the payloads carried by websocket messages, keyed
+// by the Header.Event they're paired with.
+package message
+
+// Header is decoded first from every incoming/outgoing websocket message to
+// learn which event it carries, before the rest of the payload is decoded
+// against the matching struct below.
+type Header struct {
+	Event string `json:"event"`
+}
+
+type ScreenSize struct {
+	OutputName string `json:"output_name"`
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+	Rate       int16  `json:"rate"`
+}
+
+// ClipboardEntry mirrors types.ClipboardEntry on the wire.
+type ClipboardEntry struct {
+	MimeType string `json:"mime_type"`
+	Data     []byte `json:"data"`
+}
+
+type ClipboardTargets struct {
+	MimeTypes []string `json:"mime_types"`
+}
+
+type ClipboardRead struct {
+	MimeType string `json:"mime_type"`
+}
+
+type ClipboardWrite struct {
+	Entries []ClipboardEntry `json:"entries"`
+}
+
+type ClipboardData struct {
+	MimeType string `json:"mime_type"`
+	Data     []byte `json:"data"`
+}
+
+type ControlPos struct {
+	OutputName string `json:"output_name"`
+	X          int    `json:"x"`
+	Y          int    `json:"y"`
+}
+
+type ControlButton struct {
+	OutputName string `json:"output_name"`
+	Code       int    `json:"code"`
+}
+
+type DropFiles struct {
+	OutputName string   `json:"output_name"`
+	X          int      `json:"x"`
+	Y          int      `json:"y"`
+	Files      []string `json:"files"`
+}
+
+// LayerChanged announces the simulcast RID currently being sent, whether it
+// was picked by the automatic estimator or pinned via SetPreferredLayer.
+type LayerChanged struct {
+	RID string `json:"rid"`
+}
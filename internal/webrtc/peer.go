@@ -1,12 +1,17 @@
 package webrtc
 
 import (
+	"fmt"
 	"sync"
+	"time"
 
+	"github.com/pion/rtcp"
 	"github.com/pion/webrtc/v3"
 	"github.com/rs/zerolog"
 
 	"gitlab.com/demodesk/neko/server/internal/types"
+	"gitlab.com/demodesk/neko/server/internal/types/event"
+	"gitlab.com/demodesk/neko/server/internal/types/message"
 )
 
 type WebRTCPeerCtx struct {
@@ -16,6 +21,14 @@ type WebRTCPeerCtx struct {
 	dataChannel *webrtc.DataChannel
 	changeVideo func(videoID string) error
 	iceTrickle  bool
+
+	// simulcast
+	session         types.Session
+	simulcastLayers []simulcastLayer
+	preferredRID    string
+	autoLayer       bool
+	estimator       *bandwidthEstimator
+	onLayerChanged  func(rid string)
 }
 
 func (peer *WebRTCPeerCtx) CreateOffer(ICERestart bool) (*webrtc.SessionDescription, error) {
@@ -122,6 +135,172 @@ func (peer *WebRTCPeerCtx) SetVideoID(videoID string) error {
 	return peer.changeVideo(videoID)
 }
 
+// SetPreferredLayer pins the simulcast layer sent to this peer to rid. Pass
+// an empty string to hand control back to the automatic, REMB/transport-cc
+// driven estimator.
+func (peer *WebRTCPeerCtx) SetPreferredLayer(rid string) error {
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+
+	if peer.connection == nil {
+		return types.ErrWebRTCConnectionNotFound
+	}
+
+	if rid == "" {
+		peer.autoLayer = true
+		return nil
+	}
+
+	if !peer.hasLayer(rid) {
+		return fmt.Errorf("unknown simulcast rid %q", rid)
+	}
+
+	peer.autoLayer = false
+	peer.setLayer(rid)
+	return nil
+}
+
+// OnLayerChanged registers a listener invoked whenever the effective
+// simulcast layer sent to this peer changes, whether picked manually via
+// SetPreferredLayer or automatically by the bandwidth estimator. Setting a
+// session via SetSession already wires this to the event.LAYER_CHANGED
+// websocket message; only one listener is kept, so calling OnLayerChanged
+// afterwards replaces that default behaviour (e.g. in tests).
+func (peer *WebRTCPeerCtx) OnLayerChanged(listener func(rid string)) {
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+
+	peer.onLayerChanged = listener
+}
+
+// SetSession associates this peer with the session it serves, so the
+// frontend actually learns about automatic layer switches: it wires
+// OnLayerChanged to send event.LAYER_CHANGED over the session's websocket.
+func (peer *WebRTCPeerCtx) SetSession(session types.Session) {
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+
+	peer.session = session
+	peer.onLayerChanged = func(rid string) {
+		session.Send(event.LAYER_CHANGED, message.LayerChanged{RID: rid})
+	}
+}
+
+// AddSimulcastTrack publishes track to the peer as a simulcast sender with
+// one RTP encoding per layer (lowest to highest bitrate). Pion munges those
+// encodings into the SDP produced by the next CreateOffer/CreateAnswer as
+// the standard "a=simulcast" / "a=rid" attributes, which is how the RIDs
+// actually get negotiated with the remote end. It also starts draining
+// transport-cc feedback for the sender so the bandwidth estimator runs on
+// real traffic.
+func (peer *WebRTCPeerCtx) AddSimulcastTrack(track webrtc.TrackLocal, layers []simulcastLayer) error {
+	peer.mu.Lock()
+	if peer.connection == nil {
+		peer.mu.Unlock()
+		return types.ErrWebRTCConnectionNotFound
+	}
+	connection := peer.connection
+	peer.mu.Unlock()
+
+	encodings := make([]webrtc.RTPEncodingParameters, len(layers))
+	for i, layer := range layers {
+		encodings[i] = webrtc.RTPEncodingParameters{
+			RTPCodingParameters: webrtc.RTPCodingParameters{RID: layer.RID},
+		}
+	}
+
+	transceiver, err := connection.AddTransceiverFromTrack(track, webrtc.RTPTransceiverInit{
+		Direction:     webrtc.RTPTransceiverDirectionSendonly,
+		SendEncodings: encodings,
+	})
+	if err != nil {
+		return err
+	}
+
+	peer.setSimulcastLayers(layers)
+	peer.readTransportCC(transceiver.Sender())
+
+	return nil
+}
+
+// setSimulcastLayers records the RIDs published by the capture pipeline for
+// this peer, in priority order from lowest to highest bitrate, and starts
+// the automatic bitrate estimator.
+func (peer *WebRTCPeerCtx) setSimulcastLayers(layers []simulcastLayer) {
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+
+	peer.simulcastLayers = layers
+	peer.autoLayer = true
+	peer.estimator = newBandwidthEstimator()
+}
+
+// readTransportCC drains RTCP off sender in the background for as long as
+// the connection is alive, so transport-cc feedback reports actually reach
+// handleTransportCC instead of the estimator sitting idle.
+func (peer *WebRTCPeerCtx) readTransportCC(sender *webrtc.RTPSender) {
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, _, err := sender.Read(buf)
+			if err != nil {
+				return
+			}
+
+			packets, err := rtcp.Unmarshal(buf[:n])
+			if err != nil {
+				continue
+			}
+
+			for _, pkt := range packets {
+				if tcc, ok := pkt.(*rtcp.TransportLayerCC); ok {
+					peer.handleTransportCC(tcc)
+				}
+			}
+		}
+	}()
+}
+
+func (peer *WebRTCPeerCtx) hasLayer(rid string) bool {
+	for _, layer := range peer.simulcastLayers {
+		if layer.RID == rid {
+			return true
+		}
+	}
+	return false
+}
+
+// setLayer applies rid as the effective layer and emits layer_changed,
+// assuming peer.mu is already held.
+func (peer *WebRTCPeerCtx) setLayer(rid string) {
+	if peer.preferredRID == rid {
+		return
+	}
+
+	peer.preferredRID = rid
+	peer.logger.Info().Str("rid", rid).Msg("simulcast layer changed")
+
+	if peer.onLayerChanged != nil {
+		peer.onLayerChanged(rid)
+	}
+}
+
+// handleTransportCC feeds one transport-cc feedback report into the
+// bandwidth estimator and, while in automatic mode, switches to the
+// highest RID that fits the resulting estimate.
+func (peer *WebRTCPeerCtx) handleTransportCC(pkt *rtcp.TransportLayerCC) {
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+
+	if !peer.autoLayer || peer.estimator == nil || len(peer.simulcastLayers) == 0 {
+		return
+	}
+
+	estimate := peer.estimator.Feed(pkt, time.Now())
+	rid := selectLayer(peer.simulcastLayers, peer.preferredRID, estimate)
+	peer.setLayer(rid)
+}
+
 func (peer *WebRTCPeerCtx) Destroy() {
 	peer.mu.Lock()
 	defer peer.mu.Unlock()
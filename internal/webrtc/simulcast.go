@@ -0,0 +1,128 @@
+package webrtc
+
+import (
+	"time"
+
+	"github.com/pion/rtcp"
+)
+
+// simulcastLayer pairs a RID with the approximate bitrate (bps) it needs to
+// render cleanly, so the estimator can pick the highest layer that fits.
+type simulcastLayer struct {
+	RID     string
+	Bitrate int
+}
+
+// bandwidthEstimator implements a small AIMD (additive-increase,
+// multiplicative-decrease) estimator over transport-cc feedback: it grows
+// the estimate steadily while losses are rare, and backs off sharply the
+// moment loss indicates congestion. Hysteresis is applied separately, by
+// selectLayer, so the selected layer doesn't flap near a boundary.
+type bandwidthEstimator struct {
+	estimate int // bits per second
+
+	lastIncrease time.Time
+}
+
+const (
+	// initialEstimateBps is a conservative starting point, well below a
+	// single typical simulcast layer, so we never overshoot before the
+	// first feedback report arrives.
+	initialEstimateBps = 150_000
+
+	// additiveStepBps is how much we grow the estimate per healthy report.
+	additiveStepBps = 50_000
+
+	// multiplicativeDecrease is applied to the estimate once loss crosses
+	// lossThreshold, per the standard AIMD congestion response.
+	multiplicativeDecrease = 0.7
+
+	// lossThreshold is the fraction of packets marked not received in a
+	// feedback report at or above which we treat the link as congested.
+	lossThreshold = 0.1
+
+	// minIncreaseInterval rate-limits the additive growth so a burst of
+	// feedback reports doesn't ramp the estimate up in one go.
+	minIncreaseInterval = 200 * time.Millisecond
+)
+
+func newBandwidthEstimator() *bandwidthEstimator {
+	return &bandwidthEstimator{
+		estimate: initialEstimateBps,
+	}
+}
+
+// Feed folds one transport-cc feedback report into the estimate and returns
+// the updated bits-per-second estimate.
+func (e *bandwidthEstimator) Feed(pkt *rtcp.TransportLayerCC, now time.Time) int {
+	lost, total := lossRatio(pkt)
+	if total == 0 {
+		return e.estimate
+	}
+
+	if lost >= lossThreshold {
+		e.estimate = int(float64(e.estimate) * multiplicativeDecrease)
+	} else if now.Sub(e.lastIncrease) >= minIncreaseInterval {
+		e.estimate += additiveStepBps
+		e.lastIncrease = now
+	}
+
+	if e.estimate < additiveStepBps {
+		e.estimate = additiveStepBps
+	}
+
+	return e.estimate
+}
+
+// lossRatio walks the packet status chunks of a transport-cc feedback report
+// and returns the fraction of packets marked not received, together with the
+// total packet count, for the reported range.
+func lossRatio(pkt *rtcp.TransportLayerCC) (float64, int) {
+	total := int(pkt.PacketStatusCount)
+	if total == 0 {
+		return 0, 0
+	}
+
+	lost := 0
+	for _, chunk := range pkt.PacketChunks {
+		switch c := chunk.(type) {
+		case *rtcp.RunLengthChunk:
+			if c.PacketStatusSymbol == rtcp.TypeTCCPacketNotReceived {
+				lost += int(c.RunLength)
+			}
+		case *rtcp.StatusVectorChunk:
+			for _, symbol := range c.SymbolList {
+				if symbol == rtcp.TypeTCCPacketNotReceived {
+					lost++
+				}
+			}
+		}
+	}
+
+	return float64(lost) / float64(total), total
+}
+
+// selectLayer picks the highest-bitrate layer that fits the estimate, with
+// hysteresis: a layer is only abandoned once the estimate drops clearly
+// below it, to avoid oscillating between two adjacent RIDs.
+func selectLayer(layers []simulcastLayer, currentRID string, estimateBps int) string {
+	const hysteresis = 1.2
+
+	best := ""
+	for _, layer := range layers {
+		threshold := layer.Bitrate
+		if layer.RID == currentRID {
+			threshold = int(float64(layer.Bitrate) / hysteresis)
+		}
+
+		if estimateBps >= threshold {
+			best = layer.RID
+		}
+	}
+
+	if best == "" && len(layers) > 0 {
+		best = layers[0].RID
+	}
+
+	return best
+}
@@ -0,0 +1,104 @@
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtcp"
+)
+
+func allReceivedPacket(count uint16) *rtcp.TransportLayerCC {
+	return &rtcp.TransportLayerCC{
+		PacketStatusCount: count,
+		PacketChunks: []rtcp.PacketStatusChunk{
+			&rtcp.RunLengthChunk{
+				PacketStatusSymbol: rtcp.TypeTCCPacketReceivedSmallDelta,
+				RunLength:          count,
+			},
+		},
+	}
+}
+
+func mostlyLostPacket(count uint16) *rtcp.TransportLayerCC {
+	return &rtcp.TransportLayerCC{
+		PacketStatusCount: count,
+		PacketChunks: []rtcp.PacketStatusChunk{
+			&rtcp.RunLengthChunk{
+				PacketStatusSymbol: rtcp.TypeTCCPacketNotReceived,
+				RunLength:          count,
+			},
+		},
+	}
+}
+
+func TestBandwidthEstimatorIncreasesOnHealthyFeedback(t *testing.T) {
+	e := newBandwidthEstimator()
+	start := e.estimate
+
+	now := time.Unix(0, 0)
+	now = now.Add(minIncreaseInterval)
+	estimate := e.Feed(allReceivedPacket(10), now)
+
+	if estimate <= start {
+		t.Fatalf("Feed() estimate = %d, want greater than initial %d", estimate, start)
+	}
+}
+
+func TestBandwidthEstimatorBacksOffOnLoss(t *testing.T) {
+	e := newBandwidthEstimator()
+	now := time.Unix(0, 0)
+
+	// ramp the estimate up first so the decrease is observable
+	for i := 0; i < 3; i++ {
+		now = now.Add(minIncreaseInterval)
+		e.Feed(allReceivedPacket(10), now)
+	}
+	before := e.estimate
+
+	now = now.Add(minIncreaseInterval)
+	after := e.Feed(mostlyLostPacket(10), now)
+
+	if after >= before {
+		t.Fatalf("Feed() with loss = %d, want less than %d", after, before)
+	}
+}
+
+func TestBandwidthEstimatorIgnoresEmptyReport(t *testing.T) {
+	e := newBandwidthEstimator()
+	start := e.estimate
+
+	got := e.Feed(&rtcp.TransportLayerCC{PacketStatusCount: 0}, time.Unix(0, 0))
+	if got != start {
+		t.Fatalf("Feed() with empty report = %d, want unchanged %d", got, start)
+	}
+}
+
+func TestSelectLayer(t *testing.T) {
+	layers := []simulcastLayer{
+		{RID: "low", Bitrate: 150_000},
+		{RID: "mid", Bitrate: 500_000},
+		{RID: "high", Bitrate: 1_500_000},
+	}
+
+	tests := []struct {
+		name       string
+		currentRID string
+		estimate   int
+		want       string
+	}{
+		{"below lowest falls back to first layer", "", 10_000, "low"},
+		{"fits mid exactly", "low", 500_000, "mid"},
+		{"fits highest", "mid", 1_500_000, "high"},
+		{"hysteresis keeps current just under its threshold", "high", 1_300_000, "high"},
+		{"drops once clearly below current threshold", "high", 900_000, "mid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectLayer(layers, tt.currentRID, tt.estimate)
+			if got != tt.want {
+				t.Fatalf("selectLayer() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"errors"
+
+	"gitlab.com/demodesk/neko/server/internal/types"
+	"gitlab.com/demodesk/neko/server/internal/types/event"
+	"gitlab.com/demodesk/neko/server/internal/types/message"
+)
+
+func (h *MessageHandlerCtx) clipboardTargets(session types.Session) error {
+	if !session.Profile().CanAccessClipboard {
+		return errors.New("is not allowed to access clipboard")
+	}
+
+	targets, err := h.desktop.ClipboardTargets()
+	if err != nil {
+		return err
+	}
+
+	mimeTypes := make([]string, len(targets))
+	for i, target := range targets {
+		mimeTypes[i] = target.MimeType
+	}
+
+	session.Send(event.CLIPBOARD_TARGETS, message.ClipboardTargets{
+		MimeTypes: mimeTypes,
+	})
+
+	return nil
+}
+
+func (h *MessageHandlerCtx) clipboardRead(session types.Session, payload *message.ClipboardRead) error {
+	if !session.Profile().CanAccessClipboard {
+		return errors.New("is not allowed to access clipboard")
+	}
+
+	data, err := h.desktop.ClipboardRead(payload.MimeType)
+	if err != nil {
+		return err
+	}
+
+	session.Send(event.CLIPBOARD_DATA, message.ClipboardData{
+		MimeType: payload.MimeType,
+		Data:     data,
+	})
+
+	return nil
+}
+
+func (h *MessageHandlerCtx) clipboardWrite(session types.Session, payload *message.ClipboardWrite) error {
+	if !session.Profile().CanAccessClipboard {
+		return errors.New("is not allowed to access clipboard")
+	}
+
+	entries := make([]types.ClipboardEntry, len(payload.Entries))
+	for i, entry := range payload.Entries {
+		entries[i] = types.ClipboardEntry{
+			MimeType: entry.MimeType,
+			Data:     entry.Data,
+		}
+	}
+
+	return h.desktop.ClipboardWrite(entries)
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"errors"
+
+	"gitlab.com/demodesk/neko/server/internal/types"
+	"gitlab.com/demodesk/neko/server/internal/types/message"
+)
+
+func (h *MessageHandlerCtx) controlMove(session types.Session, payload *message.ControlPos) error {
+	if !session.State().IsHosting {
+		return errors.New("is not the host")
+	}
+
+	h.desktop.Move(payload.OutputName, payload.X, payload.Y)
+	return nil
+}
+
+func (h *MessageHandlerCtx) controlButtonDown(session types.Session, payload *message.ControlButton) error {
+	if !session.State().IsHosting {
+		return errors.New("is not the host")
+	}
+
+	return h.desktop.ButtonDown(payload.OutputName, payload.Code)
+}
@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"errors"
+
+	"gitlab.com/demodesk/neko/server/internal/types"
+	"gitlab.com/demodesk/neko/server/internal/types/message"
+)
+
+func (h *MessageHandlerCtx) fileDrop(session types.Session, payload *message.DropFiles) error {
+	if !session.State().IsHosting {
+		return errors.New("is not the host")
+	}
+
+	h.desktop.DropFiles(payload.OutputName, payload.X, payload.Y, payload.Files)
+	return nil
+}
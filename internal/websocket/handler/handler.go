@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gitlab.com/demodesk/neko/server/internal/types"
+	"gitlab.com/demodesk/neko/server/internal/types/event"
+	"gitlab.com/demodesk/neko/server/internal/types/message"
+)
+
+// MessageHandlerCtx dispatches incoming websocket messages to the handler
+// function for their event, giving each handler access to the subsystems
+// (desktop, sessions) it needs to act on the message.
+type MessageHandlerCtx struct {
+	desktop  types.DesktopManager
+	sessions types.SessionManager
+}
+
+func New(desktop types.DesktopManager, sessions types.SessionManager) *MessageHandlerCtx {
+	return &MessageHandlerCtx{
+		desktop:  desktop,
+		sessions: sessions,
+	}
+}
+
+// Message decodes raw according to header.Event and routes it to the
+// matching handler. An unknown event is not an error: older/newer clients
+// may speak about events this server revision doesn't know about.
+func (h *MessageHandlerCtx) Message(session types.Session, header message.Header, raw []byte) error {
+	switch header.Event {
+	case event.SCREEN_SET:
+		payload := &message.ScreenSize{}
+		if err := json.Unmarshal(raw, payload); err != nil {
+			return fmt.Errorf("unable to unmarshal %s payload: %w", header.Event, err)
+		}
+		return h.screenSet(session, payload)
+
+	case event.CLIPBOARD_TARGETS:
+		return h.clipboardTargets(session)
+
+	case event.CLIPBOARD_READ:
+		payload := &message.ClipboardRead{}
+		if err := json.Unmarshal(raw, payload); err != nil {
+			return fmt.Errorf("unable to unmarshal %s payload: %w", header.Event, err)
+		}
+		return h.clipboardRead(session, payload)
+
+	case event.CLIPBOARD_WRITE:
+		payload := &message.ClipboardWrite{}
+		if err := json.Unmarshal(raw, payload); err != nil {
+			return fmt.Errorf("unable to unmarshal %s payload: %w", header.Event, err)
+		}
+		return h.clipboardWrite(session, payload)
+
+	case event.CONTROL_MOVE:
+		payload := &message.ControlPos{}
+		if err := json.Unmarshal(raw, payload); err != nil {
+			return fmt.Errorf("unable to unmarshal %s payload: %w", header.Event, err)
+		}
+		return h.controlMove(session, payload)
+
+	case event.CONTROL_BUTTONDOWN:
+		payload := &message.ControlButton{}
+		if err := json.Unmarshal(raw, payload); err != nil {
+			return fmt.Errorf("unable to unmarshal %s payload: %w", header.Event, err)
+		}
+		return h.controlButtonDown(session, payload)
+
+	case event.FILE_DROP:
+		payload := &message.DropFiles{}
+		if err := json.Unmarshal(raw, payload); err != nil {
+			return fmt.Errorf("unable to unmarshal %s payload: %w", header.Event, err)
+		}
+		return h.fileDrop(session, payload)
+
+	default:
+		return nil
+	}
+}
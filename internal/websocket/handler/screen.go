@@ -13,8 +13,14 @@ func (h *MessageHandlerCtx) screenSet(session types.Session, payload *message.Sc
 		return errors.New("is not the admin")
 	}
 
-	data := types.ScreenSize(*payload)
-	if err := h.desktop.SetScreenSize(data); err != nil {
+	output := types.Output{
+		Name:   payload.OutputName,
+		Width:  payload.Width,
+		Height: payload.Height,
+		Rate:   payload.Rate,
+	}
+
+	if err := h.desktop.ConfigureOutputs([]types.Output{output}); err != nil {
 		return err
 	}
 
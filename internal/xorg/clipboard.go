@@ -0,0 +1,52 @@
+package xorg
+
+import (
+	"gitlab.com/demodesk/neko/server/internal/types"
+	"gitlab.com/demodesk/neko/server/internal/xorg/xevent"
+)
+
+// ClipboardTargets asks the current X11 selection owner for its TARGETS
+// atom, so a client can see which MIME types are on offer before reading.
+func (m *DesktopManagerCtx) ClipboardTargets() ([]types.ClipboardTarget, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mimeTypes, err := xevent.ClipboardTargets()
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]types.ClipboardTarget, len(mimeTypes))
+	for i, mimeType := range mimeTypes {
+		targets[i] = types.ClipboardTarget{MimeType: mimeType}
+	}
+
+	return targets, nil
+}
+
+// ClipboardRead converts the current selection to mimeType and returns its
+// raw bytes, e.g. "text/html" or "image/png" instead of a lossy plain string.
+func (m *DesktopManagerCtx) ClipboardRead(mimeType string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return xevent.ClipboardRead(mimeType)
+}
+
+// ClipboardWrite takes ownership of the X11 selection via xevent.ClipboardWrite.
+// Only the last entry stays stored, and TARGETS and conversion requests from
+// other applications are not answered.
+func (m *DesktopManagerCtx) ClipboardWrite(entries []types.ClipboardEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	xevEntries := make([]xevent.ClipboardEntry, len(entries))
+	for i, entry := range entries {
+		xevEntries[i] = xevent.ClipboardEntry{
+			MimeType: entry.MimeType,
+			Data:     entry.Data,
+		}
+	}
+
+	return xevent.ClipboardWrite(xevEntries)
+}
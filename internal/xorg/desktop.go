@@ -0,0 +1,163 @@
+package xorg
+
+import (
+	"fmt"
+
+	"gitlab.com/demodesk/neko/server/internal/types"
+	"gitlab.com/demodesk/neko/server/internal/xorg/xevent"
+)
+
+// GetOutputs lists every connected, enabled monitor via XRandR.
+func (m *DesktopManagerCtx) GetOutputs() ([]types.Output, error) {
+	outputs, err := xevent.GetOutputs()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.Output, len(outputs))
+	for i, output := range outputs {
+		result[i] = types.Output{
+			Name:    output.Name,
+			X:       output.X,
+			Y:       output.Y,
+			Width:   output.Width,
+			Height:  output.Height,
+			Rate:    output.Rate,
+			Primary: output.Primary,
+		}
+	}
+
+	return result, nil
+}
+
+// ConfigureOutputs applies a new mode to each output in turn, running the
+// before/after screen size change listeners around the whole batch so
+// capture only pauses and resumes once, not once per output.
+func (m *DesktopManagerCtx) ConfigureOutputs(outputs []types.Output) error {
+	m.mu.Lock()
+	before := append([]func(){}, m.beforeScreenSizeChange...)
+	after := append([]func(){}, m.afterScreenSizeChange...)
+	m.mu.Unlock()
+
+	for _, listener := range before {
+		listener()
+	}
+
+	var firstErr error
+	for _, output := range outputs {
+		if err := xevent.ConfigureOutput(output.Name, output.Width, output.Height, output.Rate); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for _, listener := range after {
+		listener()
+	}
+
+	if updated, err := m.GetOutputs(); err == nil {
+		m.cacheOutputs(updated)
+	}
+
+	return firstErr
+}
+
+// Move warps the pointer to x, y within outputName's local coordinate
+// space, translating it to the absolute screen coordinates XTest expects.
+func (m *DesktopManagerCtx) Move(outputName string, x, y int) {
+	output, ok := m.cachedOutput(outputName)
+	if !ok {
+		xevent.Move(x, y)
+		return
+	}
+
+	xevent.Move(output.X+x, output.Y+y)
+}
+
+// Scroll injects a relative scroll in XTest wheel clicks.
+func (m *DesktopManagerCtx) Scroll(x, y int) {
+	xevent.Scroll(x, y)
+}
+
+// ButtonDown presses the given X11 button code at the pointer's current
+// position. outputName is accepted to match the per-output routing every
+// other control message carries, but a single Xorg server only ever has one
+// XTest domain to inject into.
+func (m *DesktopManagerCtx) ButtonDown(outputName string, code int) error {
+	if _, ok := m.cachedOutput(outputName); outputName != "" && !ok {
+		return fmt.Errorf("xorg: unknown output %q", outputName)
+	}
+
+	return xevent.ButtonDown(code)
+}
+
+// KeyDown injects a KeyPress for the given X keysym.
+func (m *DesktopManagerCtx) KeyDown(code uint64) error {
+	return xevent.KeyDown(code)
+}
+
+// ButtonUp injects a ButtonRelease for the given X11 button code.
+func (m *DesktopManagerCtx) ButtonUp(code int) error {
+	return xevent.ButtonUp(code)
+}
+
+// KeyUp injects a KeyRelease for the given X keysym.
+func (m *DesktopManagerCtx) KeyUp(code uint64) error {
+	return xevent.KeyUp(code)
+}
+
+// ResetKeys releases every key and button injected so far that hasn't
+// already been released, e.g. after a host disconnects mid-keypress.
+func (m *DesktopManagerCtx) ResetKeys() {
+	xevent.ResetKeys()
+}
+
+// SetKeyboardLayout switches the active XKB layout by name.
+func (m *DesktopManagerCtx) SetKeyboardLayout(layout string) {
+	if err := xevent.SetKeyboardLayout(layout); err != nil {
+		m.logger.Warn().Err(err).Str("layout", layout).Msg("unable to set keyboard layout")
+	}
+}
+
+// SetKeyboardModifiers locks NumLock/CapsLock/ScrollLock to the given state.
+func (m *DesktopManagerCtx) SetKeyboardModifiers(NumLock int, CapsLock int, ScrollLock int) {
+	xevent.SetKeyboardModifiers(NumLock, CapsLock, ScrollLock)
+}
+
+// GetCursorImage snapshots the current cursor via XFixes.
+func (m *DesktopManagerCtx) GetCursorImage() *types.CursorImage {
+	cursor, err := xevent.GetCursorImage()
+	if err != nil {
+		m.logger.Warn().Err(err).Msg("unable to read cursor image")
+		return nil
+	}
+
+	return &types.CursorImage{
+		Width:  cursor.Width,
+		Height: cursor.Height,
+		Xhot:   cursor.Xhot,
+		Yhot:   cursor.Yhot,
+		Serial: cursor.Serial,
+		Pixels: cursor.Pixels,
+	}
+}
+
+// OnCursorChanged registers a listener invoked whenever the X11 cursor
+// image changes.
+func (m *DesktopManagerCtx) OnCursorChanged(listener func(serial uint64)) {
+	xevent.OnCursorChanged(listener)
+}
+
+// OnEventError registers a listener invoked whenever the X11 connection
+// receives a protocol error.
+func (m *DesktopManagerCtx) OnEventError(listener func(error_code uint8, message string, request_code uint8, minor_code uint8)) {
+	xevent.OnEventError(listener)
+}
+
+// DropFiles moves the pointer to x, y within outputName's local coordinate
+// space and logs the dropped file list. Actually staging the files for the
+// session (so the desktop can open them) is the caller's responsibility;
+// this only performs the drag gesture's pointer placement.
+func (m *DesktopManagerCtx) DropFiles(outputName string, x int, y int, files []string) {
+	m.Move(outputName, x, y)
+	m.logger.Info().Strs("files", files).Str("output", outputName).Msg("files dropped")
+}
@@ -0,0 +1,94 @@
+package xorg
+
+import (
+	"sync"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"gitlab.com/demodesk/neko/server/internal/types"
+	"gitlab.com/demodesk/neko/server/internal/xorg/xevent"
+)
+
+// DesktopManagerCtx implements the xorg/xevent side of types.DesktopManager
+// against a running Xorg server via the Xlib/XFixes/XRandR/XTest bindings
+// in xevent.
+type DesktopManagerCtx struct {
+	mu      sync.Mutex
+	logger  zerolog.Logger
+	outputs map[string]types.Output
+
+	beforeScreenSizeChange []func()
+	afterScreenSizeChange  []func()
+}
+
+func New() *DesktopManagerCtx {
+	return &DesktopManagerCtx{
+		logger:  log.With().Str("module", "xorg").Logger(),
+		outputs: map[string]types.Output{},
+	}
+}
+
+// compile-time assertion that DesktopManagerCtx satisfies types.DesktopManager.
+var _ types.DesktopManager = (*DesktopManagerCtx)(nil)
+
+// Start opens the X11 connection and begins pumping it for cursor-changed
+// and protocol error events.
+func (m *DesktopManagerCtx) Start() {
+	if err := xevent.Init(); err != nil {
+		m.logger.Panic().Err(err).Msg("unable to start xorg desktop manager")
+	}
+
+	outputs, err := m.GetOutputs()
+	if err != nil {
+		m.logger.Warn().Err(err).Msg("unable to read initial outputs")
+	} else {
+		m.cacheOutputs(outputs)
+	}
+
+	m.logger.Info().Msg("xorg desktop manager started")
+}
+
+// Shutdown closes the X11 connection.
+func (m *DesktopManagerCtx) Shutdown() error {
+	xevent.Shutdown()
+	m.logger.Info().Msg("xorg desktop manager shut down")
+	return nil
+}
+
+// OnBeforeScreenSizeChange registers a listener invoked just before
+// ConfigureOutputs applies a new screen size, e.g. so capture can pause.
+func (m *DesktopManagerCtx) OnBeforeScreenSizeChange(listener func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.beforeScreenSizeChange = append(m.beforeScreenSizeChange, listener)
+}
+
+// OnAfterScreenSizeChange registers a listener invoked once ConfigureOutputs
+// has applied a new screen size, e.g. so capture can resume at the new size.
+func (m *DesktopManagerCtx) OnAfterScreenSizeChange(listener func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.afterScreenSizeChange = append(m.afterScreenSizeChange, listener)
+}
+
+func (m *DesktopManagerCtx) cacheOutputs(outputs []types.Output) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cache := make(map[string]types.Output, len(outputs))
+	for _, output := range outputs {
+		cache[output.Name] = output
+	}
+	m.outputs = cache
+}
+
+func (m *DesktopManagerCtx) cachedOutput(name string) (types.Output, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	output, ok := m.outputs[name]
+	return output, ok
+}
@@ -0,0 +1,78 @@
+package xevent
+
+/*
+#cgo pkg-config: x11 xfixes
+#include "clipboard.h"
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// ClipboardEntry carries one MIME representation to offer as the new
+// selection owner.
+type ClipboardEntry struct {
+	MimeType string
+	Data     []byte
+}
+
+// ClipboardTargets requests the TARGETS atom from the current X11 selection
+// owner and decodes the returned atom names, mirroring what `xclip -o
+// -target TARGETS` negotiates before a paste.
+func ClipboardTargets() ([]string, error) {
+	var count C.int
+	cTargets := C.clipboard_get_targets(&count)
+	if cTargets == nil {
+		return nil, fmt.Errorf("xevent: unable to read clipboard targets")
+	}
+	defer C.clipboard_free_targets(cTargets, count)
+
+	targets := make([]string, int(count))
+	for i := 0; i < int(count); i++ {
+		cStr := C.clipboard_target_at(cTargets, C.int(i))
+		targets[i] = C.GoString(cStr)
+	}
+
+	return targets, nil
+}
+
+// ClipboardRead requests the selection converted to mimeType (e.g.
+// "text/html", "image/png", "text/uri-list") and returns its raw bytes.
+func ClipboardRead(mimeType string) ([]byte, error) {
+	cMime := C.CString(mimeType)
+	defer C.free(unsafe.Pointer(cMime))
+
+	var length C.int
+	data := C.clipboard_get_data(cMime, &length)
+	if data == nil {
+		return nil, fmt.Errorf("xevent: unable to read clipboard for mime type %q", mimeType)
+	}
+	defer C.free(unsafe.Pointer(data))
+
+	return C.GoBytes(unsafe.Pointer(data), length), nil
+}
+
+// ClipboardWrite takes ownership of the CLIPBOARD selection once per entry,
+// in order; each entry replaces the one before, so only the last entry stays
+// stored. TARGETS / conversion requests from other applications are not
+// answered.
+func ClipboardWrite(entries []ClipboardEntry) error {
+	for _, entry := range entries {
+		cMime := C.CString(entry.MimeType)
+		cData := C.CBytes(entry.Data)
+
+		ok := C.clipboard_set_data(cMime, (*C.char)(cData), C.int(len(entry.Data)))
+
+		C.free(unsafe.Pointer(cMime))
+		C.free(cData)
+
+		if ok == 0 {
+			return fmt.Errorf("xevent: unable to set clipboard for mime type %q", entry.MimeType)
+		}
+	}
+
+	return nil
+}
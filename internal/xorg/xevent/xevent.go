@@ -0,0 +1,223 @@
+package xevent
+
+/*
+#cgo pkg-config: x11 xfixes xrandr xtst xkbfile
+#cgo LDFLAGS: -lpthread
+#include "xevent.h"
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// Output mirrors one connected, enabled monitor as reported by XRandR.
+type Output struct {
+	Name    string
+	X       int
+	Y       int
+	Width   int
+	Height  int
+	Rate    int16
+	Primary bool
+}
+
+// CursorImage mirrors an XFixesCursorImage snapshot, pixels already
+// converted to 8-bit RGBA.
+type CursorImage struct {
+	Width  uint16
+	Height uint16
+	Xhot   uint16
+	Yhot   uint16
+	Serial uint64
+	Pixels []byte
+}
+
+var (
+	listenersMu       sync.Mutex
+	cursorListeners   []func(serial uint64)
+	eventErrListeners []func(errorCode uint8, message string, requestCode uint8, minorCode uint8)
+)
+
+// Init opens the shared X11 connection and starts pumping it for
+// XFixesCursorNotify and X protocol error events.
+func Init() error {
+	if C.xevent_init() == 0 {
+		return fmt.Errorf("xevent: unable to open X11 display")
+	}
+	return nil
+}
+
+// Shutdown stops the event pump and closes the X11 connection.
+func Shutdown() {
+	C.xevent_shutdown()
+}
+
+// OnCursorChanged registers a listener invoked whenever the X11 cursor
+// image changes (new shape, or a new serial for the same shape).
+func OnCursorChanged(listener func(serial uint64)) {
+	listenersMu.Lock()
+	defer listenersMu.Unlock()
+
+	cursorListeners = append(cursorListeners, listener)
+}
+
+// OnEventError registers a listener invoked whenever the X11 connection
+// receives a protocol error, mirroring the fields of an XErrorEvent.
+func OnEventError(listener func(errorCode uint8, message string, requestCode uint8, minorCode uint8)) {
+	listenersMu.Lock()
+	defer listenersMu.Unlock()
+
+	eventErrListeners = append(eventErrListeners, listener)
+}
+
+//export goCursorChanged
+func goCursorChanged(serial C.ulong) {
+	listenersMu.Lock()
+	listeners := cursorListeners
+	listenersMu.Unlock()
+
+	for _, listener := range listeners {
+		listener(uint64(serial))
+	}
+}
+
+//export goEventError
+func goEventError(errorCode C.uchar, message *C.char, requestCode C.uchar, minorCode C.uchar) {
+	listenersMu.Lock()
+	listeners := eventErrListeners
+	listenersMu.Unlock()
+
+	msg := C.GoString(message)
+	for _, listener := range listeners {
+		listener(uint8(errorCode), msg, uint8(requestCode), uint8(minorCode))
+	}
+}
+
+// GetOutputs lists every connected, enabled monitor via XRandR.
+func GetOutputs() ([]Output, error) {
+	const max = 16
+	cOutputs := make([]C.xevent_output, max)
+
+	count := int(C.xevent_get_outputs(&cOutputs[0], C.int(max)))
+
+	outputs := make([]Output, count)
+	for i := 0; i < count; i++ {
+		o := cOutputs[i]
+		outputs[i] = Output{
+			Name:    C.GoString(&o.name[0]),
+			X:       int(o.x),
+			Y:       int(o.y),
+			Width:   int(o.width),
+			Height:  int(o.height),
+			Rate:    int16(o.rate),
+			Primary: o.primary != 0,
+		}
+	}
+
+	return outputs, nil
+}
+
+// ConfigureOutput resizes the named output's CRTC to the given mode, like
+// `xrandr --output <name> --mode <width>x<height> --rate <rate>`; rate 0
+// matches any rate. The screen is set to exactly width x height and the
+// output is placed at 0,0; other outputs are not taken into account.
+func ConfigureOutput(name string, width, height int, rate int16) error {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	if C.xevent_configure_output(cName, C.int(width), C.int(height), C.short(rate)) == 0 {
+		return fmt.Errorf("xevent: unable to configure output %q to %dx%d@%d", name, width, height, rate)
+	}
+	return nil
+}
+
+// Move warps the pointer to absolute screen coordinates x, y.
+func Move(x, y int) {
+	C.xevent_move(C.int(x), C.int(y))
+}
+
+// Scroll injects a relative scroll in XTest wheel clicks.
+func Scroll(x, y int) {
+	C.xevent_scroll(C.int(x), C.int(y))
+}
+
+// KeyDown injects a KeyPress for the given X keysym.
+func KeyDown(keysym uint64) error {
+	if C.xevent_key_down(C.ulong(keysym)) == 0 {
+		return fmt.Errorf("xevent: no keycode mapped for keysym %#x", keysym)
+	}
+	return nil
+}
+
+// KeyUp injects a KeyRelease for the given X keysym.
+func KeyUp(keysym uint64) error {
+	if C.xevent_key_up(C.ulong(keysym)) == 0 {
+		return fmt.Errorf("xevent: no keycode mapped for keysym %#x", keysym)
+	}
+	return nil
+}
+
+// ButtonDown injects a ButtonPress for the given X11 button code.
+func ButtonDown(code int) error {
+	if C.xevent_button_down(C.int(code)) == 0 {
+		return fmt.Errorf("xevent: unable to press button %d", code)
+	}
+	return nil
+}
+
+// ButtonUp injects a ButtonRelease for the given X11 button code.
+func ButtonUp(code int) error {
+	if C.xevent_button_up(C.int(code)) == 0 {
+		return fmt.Errorf("xevent: unable to release button %d", code)
+	}
+	return nil
+}
+
+// ResetKeys releases every key and button injected so far that hasn't
+// already been released.
+func ResetKeys() {
+	C.xevent_reset_keys()
+}
+
+// SetKeyboardLayout switches the active XKB layout by name (e.g. "us",
+// "de"), same as `setxkbmap -layout <name>`.
+func SetKeyboardLayout(layout string) error {
+	cLayout := C.CString(layout)
+	defer C.free(unsafe.Pointer(cLayout))
+
+	if C.xevent_set_keyboard_layout(cLayout) == 0 {
+		return fmt.Errorf("xevent: unable to set keyboard layout %q", layout)
+	}
+	return nil
+}
+
+// SetKeyboardModifiers locks NumLock/CapsLock/ScrollLock to the given
+// state. Pass -1 for a modifier to leave it untouched.
+func SetKeyboardModifiers(numLock int, capsLock int, scrollLock int) {
+	C.xevent_set_keyboard_modifiers(C.int(numLock), C.int(capsLock), C.int(scrollLock))
+}
+
+// GetCursorImage snapshots the current cursor via XFixes.
+func GetCursorImage() (*CursorImage, error) {
+	cCursor := C.xevent_get_cursor_image()
+	if cCursor == nil {
+		return nil, fmt.Errorf("xevent: unable to read cursor image")
+	}
+	defer C.free(unsafe.Pointer(cCursor.pixels))
+	defer C.free(unsafe.Pointer(cCursor))
+
+	n := int(cCursor.width) * int(cCursor.height) * 4
+
+	return &CursorImage{
+		Width:  uint16(cCursor.width),
+		Height: uint16(cCursor.height),
+		Xhot:   uint16(cCursor.xhot),
+		Yhot:   uint16(cCursor.yhot),
+		Serial: uint64(cCursor.serial),
+		Pixels: C.GoBytes(unsafe.Pointer(cCursor.pixels), C.int(n)),
+	}, nil
+}